@@ -2,17 +2,53 @@ package rfc6979
 
 import (
 	"crypto/dsa"
+	"hash"
 	"math/big"
 )
 
+// VerifyDSA verifies the signature (r, s) of hash using the public key, pub.
+// Its return value records whether the signature is valid.
+func VerifyDSA(pub *dsa.PublicKey, hash []byte, r, s *big.Int) bool {
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(pub.Q) >= 0 || s.Cmp(pub.Q) >= 0 {
+		return false
+	}
+
+	w := new(big.Int).ModInverse(s, pub.Q)
+
+	z := new(big.Int).SetBytes(hash)
+
+	u1 := new(big.Int).Mul(z, w)
+	u1.Mod(u1, pub.Q)
+
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, pub.Q)
+
+	v1 := new(big.Int).Exp(pub.G, u1, pub.P)
+	v2 := new(big.Int).Exp(pub.Y, u2, pub.P)
+
+	v := v1.Mul(v1, v2)
+	v.Mod(v, pub.P)
+	v.Mod(v, pub.Q)
+
+	return v.Cmp(r) == 0
+}
+
 // Sign signs an arbitrary length hash (which should be the result of hashing a
 // larger message) using the private key, priv. It returns the signature as a
 // pair of integers.
 //
 // Note that FIPS 186-3 section 4.6 specifies that the hash should be truncated
 // to the byte-length of the subgroup. This function does not perform that
-// truncation itself.
+// truncation itself; sign via SignDSAHash, if hash may be longer than that.
 func SignDSA(priv *dsa.PrivateKey, hash []byte, alg HashAlgorithm) (r, s *big.Int, err error) {
+	return SignDSAWithExtra(priv, hash, alg, nil)
+}
+
+// SignDSAWithExtra is like SignDSA, but mixes extra into the HMAC-DRBG seed
+// as permitted by RFC 6979 section 3.6, producing a "hedged" signature (see
+// SignECDSAWithExtra). Passing nil extra reproduces the signature SignDSA
+// would produce.
+func SignDSAWithExtra(priv *dsa.PrivateKey, hash []byte, alg HashAlgorithm, extra []byte) (r, s *big.Int, err error) {
 	n := priv.Q.BitLen()
 	if n&7 != 0 {
 		err = dsa.ErrInvalidPublicKey
@@ -20,7 +56,7 @@ func SignDSA(priv *dsa.PrivateKey, hash []byte, alg HashAlgorithm) (r, s *big.In
 	}
 	n >>= 3
 
-	generateSecret(priv.Q, priv.X, alg, hash, func(k *big.Int) bool {
+	generateSecret(priv.Q, priv.X, alg, hash, extra, func(k *big.Int) bool {
 		kInv := new(big.Int).ModInverse(k, priv.Q)
 		r = new(big.Int).Exp(priv.G, k, priv.P)
 		r.Mod(r, priv.Q)
@@ -42,3 +78,14 @@ func SignDSA(priv *dsa.PrivateKey, hash []byte, alg HashAlgorithm) (r, s *big.In
 
 	return
 }
+
+// SignDSAHash signs a message using the private key, priv, given a hash.Hash
+// that the caller has already written the message to incrementally (e.g. via
+// repeated calls to h.Write). It truncates the resulting digest to the
+// byte-length of Q before signing, so callers need not hold the entire
+// message in memory or worry about the truncation footgun documented on
+// SignDSA.
+func SignDSAHash(priv *dsa.PrivateKey, h hash.Hash, alg HashAlgorithm) (r, s *big.Int, err error) {
+	sum := truncateHash(h.Sum(nil), priv.Q.BitLen())
+	return SignDSA(priv, sum, alg)
+}
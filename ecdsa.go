@@ -3,6 +3,7 @@ package rfc6979
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"errors"
 	"hash"
 	"math/big"
 )
@@ -13,12 +14,25 @@ import (
 //
 // Note that FIPS 186-3 section 4.6 specifies that the hash should be truncated
 // to the byte-length of the subgroup. This function does not perform that
-// truncation itself.
+// truncation itself; use TruncateHash, or sign via SignECDSAHash, if hash may
+// be longer than that.
 func SignECDSA(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (r, s *big.Int, err error) {
+	return SignECDSAWithExtra(priv, hash, alg, nil)
+}
+
+// SignECDSAWithExtra is like SignECDSA, but mixes extra into the HMAC-DRBG
+// seed as permitted by RFC 6979 section 3.6. This produces a "hedged"
+// signature: one that is still deterministic for a fixed extra value, but
+// which remains safe even if the caller's source of extra is partially
+// predictable, or if priv.D is ever exposed via a side channel, as long as
+// extra is not. Passing nil extra reproduces the signature SignECDSA would
+// produce; any other value yields a signature that verifies the same but is
+// no longer bit-identical across invocations with different extra.
+func SignECDSAWithExtra(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, extra []byte) (r, s *big.Int, err error) {
 	c := priv.PublicKey.Curve
 	N := c.Params().N
 
-	generateSecret(N, priv.D, alg, hash, func(k *big.Int) bool {
+	generateSecret(N, priv.D, alg, hash, extra, func(k *big.Int) bool {
 		inv := new(big.Int).ModInverse(k, N)
 		r, _ = priv.Curve.ScalarBaseMult(k.Bytes())
 		r.Mod(r, N)
@@ -39,6 +53,165 @@ func SignECDSA(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (r, s
 	return
 }
 
+// VerifyECDSA verifies the signature (r, s) of hash using the public key,
+// pub. Its return value records whether the signature is valid.
+func VerifyECDSA(pub *ecdsa.PublicKey, hash []byte, r, s *big.Int) bool {
+	c := pub.Curve
+	N := c.Params().N
+
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(N) >= 0 || s.Cmp(N) >= 0 {
+		return false
+	}
+
+	e := hashToInt(hash, c)
+	w := new(big.Int).ModInverse(s, N)
+
+	u1 := e.Mul(e, w)
+	u1.Mod(u1, N)
+	u2 := w.Mul(r, w)
+	u2.Mod(u2, N)
+
+	x1, y1 := c.ScalarBaseMult(u1.Bytes())
+	x2, y2 := c.ScalarMult(pub.X, pub.Y, u2.Bytes())
+	x, y := c.Add(x1, y1, x2, y2)
+
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return false
+	}
+
+	x.Mod(x, N)
+	return x.Cmp(r) == 0
+}
+
+// SignECDSARecoverable signs an arbitrary length hash using the private key,
+// priv, as with SignECDSA, but additionally returns a recovery byte, v, which
+// identifies which of the (at most four) candidate public keys produced the
+// signature. v encodes the parity of R's y-coordinate in its low bit and
+// whether R's x-coordinate had to be reduced mod N in its second-lowest bit,
+// matching the convention used by secp256k1/Ethereum signatures.
+func SignECDSARecoverable(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (r, s *big.Int, v byte, err error) {
+	c := priv.PublicKey.Curve
+	N := c.Params().N
+
+	generateSecret(N, priv.D, alg, hash, nil, func(k *big.Int) bool {
+		x, y := priv.Curve.ScalarBaseMult(k.Bytes())
+
+		r = new(big.Int).Mod(x, N)
+		if r.Sign() == 0 {
+			return false
+		}
+
+		if x.Cmp(N) >= 0 {
+			v = 2
+		} else {
+			v = 0
+		}
+		if y.Bit(0) != 0 {
+			v |= 1
+		}
+
+		inv := new(big.Int).ModInverse(k, N)
+		e := hashToInt(hash, c)
+		s = new(big.Int).Mul(priv.D, r)
+		s.Add(s, e)
+		s.Mul(s, inv)
+		s.Mod(s, N)
+
+		return s.Sign() != 0
+	})
+
+	return
+}
+
+// RecoverPublicKey recovers the public key used to produce the signature
+// (r, s, v) of hash, as returned by SignECDSARecoverable. curve must be a
+// short-Weierstrass curve, y^2 = x^3 + ax + b; the NIST curves in package
+// crypto/elliptic (a = -3) and secp256k1-style curves such as Ethereum's
+// (a = 0) are both supported via curveA, which recognizes the NIST curves by
+// name and otherwise assumes a = 0. A curve using some other a cannot be
+// recovered against correctly.
+func RecoverPublicKey(curve elliptic.Curve, hash []byte, r, s *big.Int, v byte) (*ecdsa.PublicKey, error) {
+	params := curve.Params()
+	N := params.N
+	P := params.P
+
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(N) >= 0 || s.Cmp(N) >= 0 {
+		return nil, errors.New("rfc6979: invalid signature: r or s out of range")
+	}
+
+	x := new(big.Int).Set(r)
+	if v&2 != 0 {
+		x.Add(x, N)
+		if x.Cmp(P) >= 0 {
+			return nil, errors.New("rfc6979: invalid recovery id: x out of range")
+		}
+	}
+
+	// y^2 = x^3 + ax + b
+	y2 := new(big.Int).Mul(x, x)
+	y2.Mul(y2, x)
+	ax := new(big.Int).Mul(curveA(curve), x)
+	y2.Add(y2, ax)
+	y2.Add(y2, params.B)
+	y2.Mod(y2, P)
+
+	y := new(big.Int).ModSqrt(y2, P)
+	if y == nil {
+		return nil, errors.New("rfc6979: invalid recovery id: no point on curve for r")
+	}
+	if y.Bit(0) != uint(v&1) {
+		y.Sub(P, y)
+	}
+
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("rfc6979: invalid recovery id: point not on curve")
+	}
+
+	e := hashToInt(hash, curve)
+	rInv := new(big.Int).ModInverse(r, N)
+
+	u1 := new(big.Int).Neg(e)
+	u1.Mul(u1, rInv)
+	u1.Mod(u1, N)
+
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, N)
+
+	x1, y1 := curve.ScalarBaseMult(u1.Bytes())
+	x2, y2r := curve.ScalarMult(x, y, u2.Bytes())
+	qx, qy := curve.Add(x1, y1, x2, y2r)
+
+	if qx.Sign() == 0 && qy.Sign() == 0 {
+		return nil, errors.New("rfc6979: invalid recovery id: recovered point at infinity")
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: qx, Y: qy}, nil
+}
+
+// SignECDSAHash signs a message using the private key, priv, given a
+// hash.Hash that the caller has already written the message to
+// incrementally (e.g. via repeated calls to h.Write). It truncates the
+// resulting digest to the byte-length of the curve's subgroup order before
+// signing, so callers need not hold the entire message in memory or worry
+// about the truncation footgun documented on SignECDSA.
+func SignECDSAHash(priv *ecdsa.PrivateKey, h hash.Hash, alg func() hash.Hash) (r, s *big.Int, err error) {
+	sum := TruncateHash(h.Sum(nil), priv.PublicKey.Curve)
+	return SignECDSA(priv, sum, alg)
+}
+
+// curveA returns the short-Weierstrass a coefficient (y^2 = x^3 + ax + b) for
+// curve, identifying the NIST curves shipped by package crypto/elliptic by
+// name (a = -3) and otherwise defaulting to a = 0, the convention used by
+// secp256k1 and other Bitcoin/Ethereum-style curves.
+func curveA(curve elliptic.Curve) *big.Int {
+	switch curve.Params().Name {
+	case "P-224", "P-256", "P-384", "P-521":
+		return new(big.Int).Sub(curve.Params().P, big.NewInt(3))
+	default:
+		return big.NewInt(0)
+	}
+}
+
 // copied from crypto/ecdsa
 func hashToInt(hash []byte, c elliptic.Curve) *big.Int {
 	orderBits := c.Params().N.BitLen()
@@ -0,0 +1,40 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+)
+
+func TestRecoverPublicKeyNIST(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	hash := sha256.Sum256([]byte("recover me"))
+	r, s, v, err := SignECDSARecoverable(priv, hash[:], sha256.New)
+	if err != nil {
+		t.Fatalf("SignECDSARecoverable: %v", err)
+	}
+
+	pub, err := RecoverPublicKey(elliptic.P256(), hash[:], r, s, v)
+	if err != nil {
+		t.Fatalf("RecoverPublicKey: %v", err)
+	}
+	if pub.X.Cmp(priv.PublicKey.X) != 0 || pub.Y.Cmp(priv.PublicKey.Y) != 0 {
+		t.Fatalf("recovered key does not match signer's public key")
+	}
+}
+
+func TestCurveAKnownCurves(t *testing.T) {
+	for _, c := range []elliptic.Curve{elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		want := new(big.Int).Sub(c.Params().P, big.NewInt(3))
+		if curveA(c).Cmp(want) != 0 {
+			t.Errorf("curveA(%s) = %v, want %v", c.Params().Name, curveA(c), want)
+		}
+	}
+}
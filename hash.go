@@ -0,0 +1,21 @@
+package rfc6979
+
+import "crypto/elliptic"
+
+// TruncateHash truncates hash to the byte-length of curve's subgroup order,
+// as required by FIPS 186-3 section 4.6. SignECDSAHash applies it to the
+// digest before signing. SignECDSA and SignECDSAWithExtra only use the
+// leftmost bits of hash, up to the bit-length of the subgroup order, so a
+// hash passed through TruncateHash first yields the same signature as the
+// full hash.
+func TruncateHash(hash []byte, curve elliptic.Curve) []byte {
+	return truncateHash(hash, curve.Params().N.BitLen())
+}
+
+func truncateHash(hash []byte, bitLen int) []byte {
+	orderBytes := (bitLen + 7) / 8
+	if len(hash) > orderBytes {
+		return hash[:orderBytes]
+	}
+	return hash
+}
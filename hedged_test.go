@@ -0,0 +1,108 @@
+package rfc6979
+
+import (
+	"bytes"
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestSignECDSAWithExtraHedged(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	digest := sha256.Sum256([]byte("hedge me"))
+
+	// A nil extra must reproduce the plain, fully deterministic signature.
+	r0, s0, err := SignECDSA(priv, digest[:], sha256.New)
+	if err != nil {
+		t.Fatalf("SignECDSA: %v", err)
+	}
+	r1, s1, err := SignECDSAWithExtra(priv, digest[:], sha256.New, nil)
+	if err != nil {
+		t.Fatalf("SignECDSAWithExtra: %v", err)
+	}
+	if r0.Cmp(r1) != 0 || s0.Cmp(s1) != 0 {
+		t.Fatalf("nil extra diverged from SignECDSA: got (%v,%v), want (%v,%v)", r1, s1, r0, s0)
+	}
+
+	// Fixed extra bytes must be fully deterministic across invocations...
+	extraA := []byte("test vector extra data A")
+	r2, s2, err := SignECDSAWithExtra(priv, digest[:], sha256.New, extraA)
+	if err != nil {
+		t.Fatalf("SignECDSAWithExtra: %v", err)
+	}
+	r3, s3, err := SignECDSAWithExtra(priv, digest[:], sha256.New, extraA)
+	if err != nil {
+		t.Fatalf("SignECDSAWithExtra: %v", err)
+	}
+	if r2.Cmp(r3) != 0 || s2.Cmp(s3) != 0 {
+		t.Fatalf("same extra produced different signatures: (%v,%v) vs (%v,%v)", r2, s2, r3, s3)
+	}
+	if !VerifyECDSA(&priv.PublicKey, digest[:], r2, s2) {
+		t.Fatalf("hedged signature with extraA does not verify")
+	}
+
+	// ...but different extra bytes must change the nonce (and so r, s),
+	// while still producing a valid signature.
+	extraB := []byte("test vector extra data B")
+	r4, s4, err := SignECDSAWithExtra(priv, digest[:], sha256.New, extraB)
+	if err != nil {
+		t.Fatalf("SignECDSAWithExtra: %v", err)
+	}
+	if r2.Cmp(r4) == 0 && s2.Cmp(s4) == 0 {
+		t.Fatalf("different extra produced the same signature")
+	}
+	if !VerifyECDSA(&priv.PublicKey, digest[:], r4, s4) {
+		t.Fatalf("hedged signature with extraB does not verify")
+	}
+}
+
+func TestSignDSAWithExtraHedged(t *testing.T) {
+	params := new(dsa.Parameters)
+	if err := dsa.GenerateParameters(params, rand.Reader, dsa.L1024N160); err != nil {
+		t.Fatalf("GenerateParameters: %v", err)
+	}
+	priv := new(dsa.PrivateKey)
+	priv.Parameters = *params
+	if err := dsa.GenerateKey(priv, rand.Reader); err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	digest := sha256.Sum256([]byte("hedge me"))
+	hash := digest[:20] // truncate to Q's byte-length, as SignDSA requires
+
+	r0, s0, err := SignDSA(priv, hash, sha256.New)
+	if err != nil {
+		t.Fatalf("SignDSA: %v", err)
+	}
+	r1, s1, err := SignDSAWithExtra(priv, hash, sha256.New, nil)
+	if err != nil {
+		t.Fatalf("SignDSAWithExtra: %v", err)
+	}
+	if r0.Cmp(r1) != 0 || s0.Cmp(s1) != 0 {
+		t.Fatalf("nil extra diverged from SignDSA: got (%v,%v), want (%v,%v)", r1, s1, r0, s0)
+	}
+
+	extra := []byte("test vector extra data")
+	r2, s2, err := SignDSAWithExtra(priv, hash, sha256.New, extra)
+	if err != nil {
+		t.Fatalf("SignDSAWithExtra: %v", err)
+	}
+	r3, s3, err := SignDSAWithExtra(priv, hash, sha256.New, extra)
+	if err != nil {
+		t.Fatalf("SignDSAWithExtra: %v", err)
+	}
+	if r2.Cmp(r3) != 0 || s2.Cmp(s3) != 0 {
+		t.Fatalf("same extra produced different signatures: (%v,%v) vs (%v,%v)", r2, s2, r3, s3)
+	}
+	if !VerifyDSA(&priv.PublicKey, hash, r2, s2) {
+		t.Fatalf("hedged DSA signature does not verify")
+	}
+	if bytes.Equal(r0.Bytes(), r2.Bytes()) && bytes.Equal(s0.Bytes(), s2.Bytes()) {
+		t.Fatalf("extra did not change the DSA signature")
+	}
+}
@@ -79,7 +79,11 @@ func bits2octets(in []byte, q *big.Int, qlen, rolen int) []byte {
 }
 
 // https://tools.ietf.org/html/rfc6979#section-3.2
-func generateSecret(q, x *big.Int, alg HashAlgorithm, hash []byte, test func(*big.Int) bool) {
+//
+// extra, if non-nil, is appended to the HMAC-DRBG seed material as permitted
+// by RFC 6979 section 3.6, producing "hedged" signatures that mix in fresh
+// randomness without giving up determinism when extra is reused.
+func generateSecret(q, x *big.Int, alg HashAlgorithm, hash, extra []byte, test func(*big.Int) bool) {
 	// Step A
 	qlen := q.BitLen()
 	holen := alg().Size()
@@ -95,6 +99,9 @@ func generateSecret(q, x *big.Int, alg HashAlgorithm, hash []byte, test func(*bi
 	b := int2octets(x, rolen)
 	bh := bits2octets(hash, q, qlen, rolen)
 	bx := append(b, bh...)
+	if extra != nil {
+		bx = append(bx, extra...)
+	}
 
 	k = alg.mac(k, append(append(v, 0x00), bx...))
 
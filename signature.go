@@ -0,0 +1,181 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// A Signature is an ECDSA signature, represented as a pair of integers.
+type Signature struct {
+	R, S *big.Int
+}
+
+// Serialize encodes the signature using the strict DER format used by
+// Bitcoin and other systems which require canonical signatures:
+//
+//	0x30 <total-len> 0x02 <len r> r 0x02 <len s> s
+func (sig *Signature) Serialize() []byte {
+	r := asn1Int(sig.R)
+	s := asn1Int(sig.S)
+
+	length := asn1Length(len(r) + len(s))
+
+	out := make([]byte, 0, 1+len(length)+len(r)+len(s))
+	out = append(out, 0x30)
+	out = append(out, length...)
+	out = append(out, r...)
+	out = append(out, s...)
+	return out
+}
+
+// asn1Int encodes v as a DER INTEGER (tag, length, minimal two's-complement
+// big-endian bytes with a leading 0x00 inserted if the high bit would
+// otherwise be set).
+func asn1Int(v *big.Int) []byte {
+	b := v.Bytes()
+	if len(b) == 0 {
+		b = []byte{0x00}
+	}
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0x00}, b...)
+	}
+	return append(append([]byte{0x02}, asn1Length(len(b))...), b...)
+}
+
+// asn1Length encodes n as a DER length: short form (a single byte) when
+// n < 128, and long form (a length-of-length byte with the high bit set,
+// followed by n's minimal big-endian encoding) otherwise. P-521 signatures
+// are long enough to require the long form.
+func asn1Length(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+
+	var b []byte
+	for n > 0 {
+		b = append([]byte{byte(n)}, b...)
+		n >>= 8
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+// ParseDERSignature parses a strict DER-encoded signature, as produced by
+// Serialize. It rejects non-canonical encodings (indefinite lengths, negative
+// integers, and excessive zero-padding), matching the BIP-66 rules.
+func ParseDERSignature(der []byte) (*Signature, error) {
+	if len(der) < 8 || der[0] != 0x30 {
+		return nil, errors.New("rfc6979: invalid signature: malformed sequence")
+	}
+
+	length, rest, err := asn1ParseLength(der[1:])
+	if err != nil {
+		return nil, err
+	}
+	if length != len(rest) {
+		return nil, errors.New("rfc6979: invalid signature: bad sequence length")
+	}
+
+	r, rest, err := asn1Uint(rest)
+	if err != nil {
+		return nil, err
+	}
+	s, rest, err := asn1Uint(rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("rfc6979: invalid signature: trailing data")
+	}
+
+	return &Signature{R: r, S: s}, nil
+}
+
+// asn1Uint decodes a single DER INTEGER known to be non-negative, returning
+// its value and the remainder of the input.
+func asn1Uint(in []byte) (v *big.Int, rest []byte, err error) {
+	if len(in) < 2 || in[0] != 0x02 {
+		return nil, nil, errors.New("rfc6979: invalid signature: expected integer")
+	}
+
+	length, in, err := asn1ParseLength(in[1:])
+	if err != nil {
+		return nil, nil, err
+	}
+	if length == 0 || len(in) < length {
+		return nil, nil, errors.New("rfc6979: invalid signature: bad integer length")
+	}
+
+	b := in[:length]
+	if b[0]&0x80 != 0 {
+		return nil, nil, errors.New("rfc6979: invalid signature: negative integer")
+	}
+	if len(b) > 1 && b[0] == 0x00 && b[1]&0x80 == 0 {
+		return nil, nil, errors.New("rfc6979: invalid signature: excess padding")
+	}
+
+	return new(big.Int).SetBytes(b), in[length:], nil
+}
+
+// asn1ParseLength decodes a DER length (short or long form) from the start
+// of in, returning the decoded length and the remainder of the input. It
+// rejects indefinite lengths and non-minimal long-form encodings.
+func asn1ParseLength(in []byte) (length int, rest []byte, err error) {
+	if len(in) < 1 {
+		return 0, nil, errors.New("rfc6979: invalid signature: truncated length")
+	}
+
+	if in[0] < 0x80 {
+		return int(in[0]), in[1:], nil
+	}
+
+	if in[0] == 0x80 {
+		return 0, nil, errors.New("rfc6979: invalid signature: indefinite length not allowed")
+	}
+
+	n := int(in[0] &^ 0x80)
+	if n > 4 || len(in) < 1+n {
+		return 0, nil, errors.New("rfc6979: invalid signature: bad long-form length")
+	}
+
+	b := in[1 : 1+n]
+	if b[0] == 0x00 {
+		return 0, nil, errors.New("rfc6979: invalid signature: non-minimal length")
+	}
+
+	length = 0
+	for _, c := range b {
+		length = length<<8 | int(c)
+	}
+	if length < 0x80 {
+		return 0, nil, errors.New("rfc6979: invalid signature: non-minimal length")
+	}
+
+	return length, in[1+n:], nil
+}
+
+// Normalize enforces the low-S convention used by Bitcoin and other systems
+// to eliminate signature malleability: if S is greater than half the curve
+// order, it is replaced with N-S.
+func (sig *Signature) Normalize(curve elliptic.Curve) {
+	halfOrder := new(big.Int).Rsh(curve.Params().N, 1)
+	if sig.S.Cmp(halfOrder) > 0 {
+		sig.S = new(big.Int).Sub(curve.Params().N, sig.S)
+	}
+}
+
+// SignECDSADER signs an arbitrary length hash using the private key, priv,
+// and returns a low-S, DER-encoded signature suitable for systems (Bitcoin,
+// TLS, JOSE) which require canonical signature encoding.
+func SignECDSADER(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) ([]byte, error) {
+	r, s, err := SignECDSA(priv, hash, alg)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &Signature{R: r, S: s}
+	sig.Normalize(priv.PublicKey.Curve)
+	return sig.Serialize(), nil
+}
@@ -0,0 +1,61 @@
+package rfc6979
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+)
+
+func TestSerializeLongFormLength(t *testing.T) {
+	// N-1 and N-2 on P-521 encode to a combined r+s length of 136 bytes,
+	// which requires DER long-form length encoding (short form tops out at
+	// 127).
+	n := elliptic.P521().Params().N
+	r := new(big.Int).Sub(n, big.NewInt(1))
+	s := new(big.Int).Sub(n, big.NewInt(2))
+
+	der := (&Signature{R: r, S: s}).Serialize()
+
+	var asn1Sig struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(der, &asn1Sig); err != nil {
+		t.Fatalf("encoding/asn1 rejected Serialize output: %v", err)
+	}
+	if asn1Sig.R.Cmp(r) != 0 || asn1Sig.S.Cmp(s) != 0 {
+		t.Fatalf("round-tripped values differ: got r=%v s=%v, want r=%v s=%v", asn1Sig.R, asn1Sig.S, r, s)
+	}
+
+	sig, err := ParseDERSignature(der)
+	if err != nil {
+		t.Fatalf("ParseDERSignature: %v", err)
+	}
+	if sig.R.Cmp(r) != 0 || sig.S.Cmp(s) != 0 {
+		t.Fatalf("ParseDERSignature round-trip differs: got r=%v s=%v, want r=%v s=%v", sig.R, sig.S, r, s)
+	}
+}
+
+func TestParseDERSignatureRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		r, s *big.Int
+	}{
+		{"small", big.NewInt(1), big.NewInt(2)},
+		{"high-bit-set", big.NewInt(0x80), big.NewInt(0xff)},
+		{"p256-order", elliptic.P256().Params().N, elliptic.P256().Params().N},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			der := (&Signature{R: tc.r, S: tc.s}).Serialize()
+			sig, err := ParseDERSignature(der)
+			if err != nil {
+				t.Fatalf("ParseDERSignature: %v", err)
+			}
+			if sig.R.Cmp(tc.r) != 0 || sig.S.Cmp(tc.s) != 0 {
+				t.Fatalf("got r=%v s=%v, want r=%v s=%v", sig.R, sig.S, tc.r, tc.s)
+			}
+			if !bytes.Equal((&Signature{R: sig.R, S: sig.S}).Serialize(), der) {
+				t.Fatalf("re-serialization did not match original DER")
+			}
+		})
+	}
+}
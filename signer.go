@@ -0,0 +1,114 @@
+package rfc6979
+
+import (
+	"crypto"
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"errors"
+	"io"
+)
+
+// Opts carries the options used by ECDSAKey.Sign and DSAKey.Sign. Hash
+// selects the HMAC hash used by RFC 6979 and must match the hash that
+// produced the digest passed to Sign. Extra, if non-nil, is mixed into the
+// HMAC-DRBG seed as described by SignECDSAWithExtra, producing a hedged
+// signature.
+type Opts struct {
+	Hash  crypto.Hash
+	Extra []byte
+}
+
+// HashFunc implements crypto.SignerOpts.
+func (o *Opts) HashFunc() crypto.Hash {
+	return o.Hash
+}
+
+// signerHash picks the HMAC hash to use: opts.HashFunc(), if it specifies
+// one, otherwise keyHash, the key's own default. It is an error for both to
+// be unset.
+func signerHash(keyHash crypto.Hash, opts crypto.SignerOpts) (crypto.Hash, error) {
+	if opts != nil && opts.HashFunc() != 0 {
+		return opts.HashFunc(), nil
+	}
+	if keyHash != 0 {
+		return keyHash, nil
+	}
+	return 0, errors.New("rfc6979: no hash algorithm specified")
+}
+
+// An ECDSAKey is an ECDSA private key which implements crypto.Signer,
+// producing deterministic, low-S, DER-encoded signatures. It can be used
+// anywhere a crypto.Signer is required, e.g. crypto/tls or
+// crypto/x509.CreateCertificate.
+type ECDSAKey struct {
+	*ecdsa.PrivateKey
+	Hash crypto.Hash
+}
+
+// Public returns the public key corresponding to k.
+func (k *ECDSAKey) Public() crypto.PublicKey {
+	return &k.PublicKey
+}
+
+// Sign signs digest deterministically, returning a DER-encoded, low-S
+// signature. rand is ignored. The HMAC hash used by RFC 6979 is
+// opts.HashFunc(), if it specifies one, otherwise k.Hash; if opts is an
+// *Opts with a non-nil Extra, it is mixed into the signature as hedging
+// entropy.
+func (k *ECDSAKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	h, err := signerHash(k.Hash, opts)
+	if err != nil {
+		return nil, err
+	}
+	alg := h.New
+
+	var extra []byte
+	if o, ok := opts.(*Opts); ok {
+		extra = o.Extra
+	}
+
+	r, s, err := SignECDSAWithExtra(k.PrivateKey, digest, alg, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &Signature{R: r, S: s}
+	sig.Normalize(k.PublicKey.Curve)
+	return sig.Serialize(), nil
+}
+
+// A DSAKey is a DSA private key which implements crypto.Signer, producing
+// deterministic, DER-encoded signatures.
+type DSAKey struct {
+	*dsa.PrivateKey
+	Hash crypto.Hash
+}
+
+// Public returns the public key corresponding to k.
+func (k *DSAKey) Public() crypto.PublicKey {
+	return &k.PublicKey
+}
+
+// Sign signs digest deterministically, returning a DER-encoded signature.
+// rand is ignored. The HMAC hash used by RFC 6979 is opts.HashFunc(), if it
+// specifies one, otherwise k.Hash; if opts is an *Opts with a non-nil Extra,
+// it is mixed into the signature as hedging entropy.
+func (k *DSAKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	h, err := signerHash(k.Hash, opts)
+	if err != nil {
+		return nil, err
+	}
+	alg := HashAlgorithm(h.New)
+
+	var extra []byte
+	if o, ok := opts.(*Opts); ok {
+		extra = o.Extra
+	}
+
+	r, s, err := SignDSAWithExtra(k.PrivateKey, digest, alg, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&Signature{R: r, S: s}).Serialize(), nil
+}
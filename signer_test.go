@@ -0,0 +1,48 @@
+package rfc6979
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestECDSAKeySignUsesKeyHashFallback(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	key := &ECDSAKey{PrivateKey: priv, Hash: crypto.SHA256}
+
+	digest := sha256.Sum256([]byte("sign me"))
+
+	// opts.HashFunc() == 0 here (crypto.Hash(0) is its own SignerOpts), so
+	// Sign must fall back to key.Hash rather than silently ignoring it.
+	der, err := key.Sign(nil, digest[:], crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	sig, err := ParseDERSignature(der)
+	if err != nil {
+		t.Fatalf("ParseDERSignature: %v", err)
+	}
+	if !VerifyECDSA(&priv.PublicKey, digest[:], sig.R, sig.S) {
+		t.Fatalf("signature produced via key.Hash fallback does not verify")
+	}
+}
+
+func TestECDSAKeySignNoHashErrors(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	key := &ECDSAKey{PrivateKey: priv}
+
+	digest := sha256.Sum256([]byte("sign me"))
+	if _, err := key.Sign(nil, digest[:], crypto.Hash(0)); err == nil {
+		t.Fatalf("expected error when neither opts nor key.Hash specify a hash")
+	}
+}